@@ -0,0 +1,192 @@
+package dirtree
+
+import (
+	"bytes"
+	"sort"
+)
+
+// Action describes the kind of change a Change represents.
+type Action int
+
+const (
+	// Insert indicates an entry exists in the second tree but not the first.
+	Insert Action = iota
+	// Delete indicates an entry exists in the first tree but not the second.
+	Delete
+	// Modify indicates an entry exists in both trees but its content hash
+	// differs.
+	Modify
+)
+
+// String returns the name of the action.
+func (a Action) String() string {
+	switch a {
+	case Insert:
+		return "Insert"
+	case Delete:
+		return "Delete"
+	case Modify:
+		return "Modify"
+	default:
+		return "Unknown"
+	}
+}
+
+// Change describes a single difference found between two Dirent trees by
+// Diff or DiffWalk.
+type Change struct {
+	// Action is the kind of change.
+	Action Action
+	// From is the path of the entry in the first (a) tree. Empty for
+	// Insert.
+	From string
+	// To is the path of the entry in the second (b) tree. Empty for
+	// Delete.
+	To string
+	// A is the node from the first tree. Nil for Insert.
+	A *Dirent
+	// B is the node from the second tree. Nil for Delete.
+	B *Dirent
+}
+
+// Diff compares the trees rooted at a and b, and returns the insertions,
+// deletions, and modifications needed to turn a into b. The returned
+// changes are sorted by destination path (source path for deletions), so
+// the result is deterministic regardless of map iteration order.
+//
+// Diff is a merkle-trie style diff: if two corresponding nodes have equal,
+// non-nil Hash values, the subtree rooted at them is assumed unchanged and
+// is not walked. See SetHash.
+func Diff(a, b *Dirent) []Change {
+	var changes []Change
+	DiffWalk(a, b, func(c Change) bool {
+		changes = append(changes, c)
+		return true
+	})
+	sort.Slice(changes, func(i, j int) bool {
+		return changeKey(changes[i]) < changeKey(changes[j])
+	})
+	return changes
+}
+
+// DiffWalk compares the trees rooted at a and b in lockstep, calling fn for
+// each change found. If fn returns false, the walk stops early.
+//
+// Either a or b may be nil, in which case every entry in the other tree is
+// reported as Insert or Delete. An unnamed root (as created by New("")) is
+// compared regardless of its name; only child names are used to match up
+// entries between the two trees.
+func DiffWalk(a, b *Dirent, fn func(Change) bool) {
+	diffNode(a, b, fn)
+}
+
+func changeKey(c Change) string {
+	if c.To != "" {
+		return c.To
+	}
+	return c.From
+}
+
+func diffNode(a, b *Dirent, fn func(Change) bool) bool {
+	if a == nil && b == nil {
+		return true
+	}
+	if a == nil {
+		return diffInsertAll(b, fn)
+	}
+	if b == nil {
+		return diffDeleteAll(a, fn)
+	}
+
+	if a.Hash != nil && b.Hash != nil && bytes.Equal(a.Hash, b.Hash) {
+		return true
+	}
+
+	aHasChildren := len(a.children) > 0
+	bHasChildren := len(b.children) > 0
+
+	if !aHasChildren || !bHasChildren {
+		if !bytes.Equal(a.Hash, b.Hash) {
+			if !fn(Change{Action: Modify, From: a.Path(), To: b.Path(), A: a, B: b}) {
+				return false
+			}
+		}
+		if aHasChildren {
+			for _, name := range a.List() {
+				if !diffDeleteAll(a.children[name], fn) {
+					return false
+				}
+			}
+		} else if bHasChildren {
+			for _, name := range b.List() {
+				if !diffInsertAll(b.children[name], fn) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	// Both sides have children: merge-walk the sorted name lists.
+	aNames := a.List()
+	bNames := b.List()
+	i, j := 0, 0
+	for i < len(aNames) && j < len(bNames) {
+		an, bn := aNames[i], bNames[j]
+		switch {
+		case an < bn:
+			if !diffDeleteAll(a.children[an], fn) {
+				return false
+			}
+			i++
+		case an > bn:
+			if !diffInsertAll(b.children[bn], fn) {
+				return false
+			}
+			j++
+		default:
+			if !diffNode(a.children[an], b.children[bn], fn) {
+				return false
+			}
+			i++
+			j++
+		}
+	}
+	for ; i < len(aNames); i++ {
+		if !diffDeleteAll(a.children[aNames[i]], fn) {
+			return false
+		}
+	}
+	for ; j < len(bNames); j++ {
+		if !diffInsertAll(b.children[bNames[j]], fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// diffInsertAll reports b, and every descendant of b, as Insert.
+func diffInsertAll(b *Dirent, fn func(Change) bool) bool {
+	if !fn(Change{Action: Insert, To: b.Path(), B: b}) {
+		return false
+	}
+	for _, name := range b.List() {
+		if !diffInsertAll(b.children[name], fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// diffDeleteAll reports a, and every descendant of a, as Delete.
+func diffDeleteAll(a *Dirent, fn func(Change) bool) bool {
+	if !fn(Change{Action: Delete, From: a.Path(), A: a}) {
+		return false
+	}
+	for _, name := range a.List() {
+		if !diffDeleteAll(a.children[name], fn) {
+			return false
+		}
+	}
+	return true
+}
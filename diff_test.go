@@ -0,0 +1,127 @@
+package dirtree
+
+import "testing"
+
+func checkChanges(t *testing.T, got []Change, want []Change) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for i, c := range got {
+		if c.Action != want[i].Action || c.From != want[i].From || c.To != want[i].To {
+			t.Fatalf("change %d = %+v, want %+v", i, c, want[i])
+		}
+	}
+}
+
+func TestDiffInsertDelete(t *testing.T) {
+	a := New("")
+	a.Add("A")
+	aB, _ := a.Add("B")
+	aB.Add("Bx")
+
+	b := New("")
+	b.Add("A")
+	b.Add("C")
+
+	checkChanges(t, Diff(a, b), []Change{
+		{Action: Delete, From: "/B"},
+		{Action: Delete, From: "/B/Bx"},
+		{Action: Insert, To: "/C"},
+	})
+}
+
+func TestDiffModify(t *testing.T) {
+	a := New("")
+	af, _ := a.Add("file")
+	af.SetHash([]byte("v1"))
+
+	b := New("")
+	bf, _ := b.Add("file")
+	bf.SetHash([]byte("v2"))
+
+	changes := Diff(a, b)
+	checkChanges(t, changes, []Change{
+		{Action: Modify, From: "/file", To: "/file"},
+	})
+	if changes[0].A != af || changes[0].B != bf {
+		t.Errorf("expected Modify to carry both nodes, got %+v", changes[0])
+	}
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	a := New("")
+	af, _ := a.Add("file")
+	af.SetHash([]byte("v1"))
+
+	b := New("")
+	bf, _ := b.Add("file")
+	bf.SetHash([]byte("v1"))
+
+	checkChanges(t, Diff(a, b), nil)
+}
+
+func TestDiffHashShortCircuit(t *testing.T) {
+	a := New("")
+	aDir, _ := a.Add("dir")
+	aDir.SetHash([]byte("same"))
+	ax, _ := aDir.Add("x")
+	ax.SetHash([]byte("irrelevant"))
+
+	b := New("")
+	bDir, _ := b.Add("dir")
+	bDir.SetHash([]byte("same"))
+	// bDir has no children. If Diff descended into aDir despite the
+	// matching directory hash, it would report "x" as a Delete.
+
+	checkChanges(t, Diff(a, b), nil)
+}
+
+func TestDiffNilSide(t *testing.T) {
+	b := New("")
+	b.Add("A")
+	bB, _ := b.Add("B")
+	bB.Add("Bx")
+
+	checkChanges(t, Diff(nil, b), []Change{
+		{Action: Insert, To: ""},
+		{Action: Insert, To: "/A"},
+		{Action: Insert, To: "/B"},
+		{Action: Insert, To: "/B/Bx"},
+	})
+
+	checkChanges(t, Diff(b, nil), []Change{
+		{Action: Delete, From: ""},
+		{Action: Delete, From: "/A"},
+		{Action: Delete, From: "/B"},
+		{Action: Delete, From: "/B/Bx"},
+	})
+}
+
+func TestDiffMismatchedRootName(t *testing.T) {
+	a := New("left")
+	a.Add("A")
+
+	b := New("right")
+	b.Add("A")
+
+	// The roots' own names differ, but only child names are used to
+	// match up entries, so the trees should compare as identical.
+	checkChanges(t, Diff(a, b), nil)
+}
+
+// TestDiffLeafToDirectory pins down the current behavior when an entry
+// that was a leaf in a gains children in b: only the new descendants are
+// reported, with no Modify change for the entry itself.
+func TestDiffLeafToDirectory(t *testing.T) {
+	a := New("")
+	a.Add("foo")
+
+	b := New("")
+	bFoo, _ := b.Add("foo")
+	bFoo.Add("bar")
+
+	checkChanges(t, Diff(a, b), []Change{
+		{Action: Insert, To: "/foo/bar"},
+	})
+}
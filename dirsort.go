@@ -15,6 +15,16 @@ func SortReverse(dSlice []*Dirent) {
 	sort.Sort(sort.Reverse(nodeSlice(dSlice)))
 }
 
+// SortFunc returns a sort function, suitable for use as TreeOptions.Sort,
+// that orders nodes using the given less function.
+func SortFunc(less func(a, b *Dirent) bool) func([]*Dirent) {
+	return func(dSlice []*Dirent) {
+		sort.Slice(dSlice, func(i, j int) bool {
+			return less(dSlice[i], dSlice[j])
+		})
+	}
+}
+
 func (s nodeSlice) Less(i, j int) bool { return s[i].String() < s[j].String() }
 func (s nodeSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 func (s nodeSlice) Len() int           { return len(s) }
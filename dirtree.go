@@ -6,7 +6,6 @@ The propose of dirtree is to provide a way to construct a tree, that can be
 traversed and printed, where each node is a simple container for its children.
 This is useful for displaying and navigating containers whose structure can be
 represented in a generalized way by this this package.
-
 */
 package dirtree
 
@@ -24,6 +23,14 @@ type Dirent struct {
 	name     string
 	parent   *Dirent
 	children map[string]*Dirent
+
+	// Hash is an optional opaque content hash for this entry, used by
+	// Diff to detect modifications and to skip comparing subtrees that
+	// are known to be identical. When set on an entry with children, it
+	// is expected to be a function of that entry's children's hashes.
+	Hash []byte
+
+	folded bool
 }
 
 // New creates a new root node.
@@ -33,6 +40,11 @@ func New(name string) *Dirent {
 	}
 }
 
+// SetHash sets the opaque content hash for the dirent. See Dirent.Hash.
+func (d *Dirent) SetHash(hash []byte) {
+	d.Hash = hash
+}
+
 // String returns the name of the directory entry.
 func (d *Dirent) String() string {
 	if d.name == "" {
@@ -242,65 +254,15 @@ func (d *Dirent) PathDelim(delim string) string {
 	return strings.Join(parts, delim)
 }
 
-// Tree returns a string containing the pretty-printed directory tree rooted at
-// the given node.
+// Tree returns a string containing the pretty-printed directory tree rooted
+// at the given node.
 //
 // The format is similar to the UNIX/Linux "tree" utility.
 // http://mama.indstate.edu/users/ice/tree/
+//
+// Tree is a thin wrapper over TreePrinter using DefaultTreeOptions; use
+// NewTreePrinter directly to customize the indent style, depth, filtering,
+// ordering, or decoration.
 func (d *Dirent) Tree() string {
-	const (
-		linkPfx = "|-- "
-		contPfx = "|   "
-		endlPfx = "`-- "
-		blnkPfx = "    "
-	)
-
-	ss := []string{d.name}
-	nodes := d.Children()
-
-	// Reverse sort the nodes, because nodes are removed from end of list.
-	sort.Sort(sort.Reverse(nodeSlice(nodes)))
-
-	pfx := linkPfx
-	var ppfx string
-	var ps []string
-	var newChIndex int
-	var cur *Dirent
-
-	for len(nodes) > 0 {
-		cur = nodes[len(nodes)-1]
-		if cur == nil {
-			ps = ps[:len(ps)-1]
-			ppfx = strings.Join(ps, "")
-			nodes = nodes[:len(nodes)-1]
-			continue
-		}
-		if len(nodes) == 1 || nodes[len(nodes)-2] == nil {
-			pfx = endlPfx
-		}
-		ss = append(ss, fmt.Sprintf("%s%s%s", ppfx, pfx, cur.name))
-
-		if len(cur.children) > 0 {
-			if pfx == endlPfx {
-				// Last item at level, so do not continue this level line.
-				ps = append(ps, blnkPfx)
-			} else {
-				// More items at this level, so continue this level line.
-				ps = append(ps, contPfx)
-			}
-			ppfx = strings.Join(ps, "")
-
-			// Add sentinel to indicate done with depth.
-			newChIndex = len(nodes)
-			nodes[newChIndex-1] = nil
-			// Add children for next level.
-			nodes = append(nodes, cur.Children()...)
-			// Reverse sort only sub-slice containing the new children.
-			sort.Sort(sort.Reverse(nodeSlice(nodes[newChIndex:len(nodes)])))
-			pfx = linkPfx
-		} else {
-			nodes = nodes[:len(nodes)-1]
-		}
-	}
-	return strings.Join(ss, "\n")
+	return NewTreePrinter(DefaultTreeOptions()).Sprint(d)
 }
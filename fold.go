@@ -0,0 +1,56 @@
+package dirtree
+
+// Fold hides the receiver's subtree from Tree/TreePrinter rendering and
+// from VisibleChildren/ForVisibleChild, without altering the tree
+// structure. Find, Children, and Diff ignore fold state.
+func (d *Dirent) Fold() {
+	d.folded = true
+}
+
+// Unfold reveals the receiver's subtree again.
+func (d *Dirent) Unfold() {
+	d.folded = false
+}
+
+// ToggleFold flips the receiver's fold state and returns the new state.
+func (d *Dirent) ToggleFold() bool {
+	d.folded = !d.folded
+	return d.folded
+}
+
+// IsFolded reports whether the receiver is currently folded.
+func (d *Dirent) IsFolded() bool {
+	return d.folded
+}
+
+// UnfoldPath unfolds every ancestor of the node at the slash-separated path
+// p, relative to the receiver, so that "selecting" a folded descendant
+// naturally reveals it. It is a no-op if p does not resolve to an existing
+// node.
+func (d *Dirent) UnfoldPath(p string) {
+	node := d.Lookup(p)
+	if node == nil {
+		return
+	}
+	for n := node.parent; n != nil; n = n.parent {
+		n.folded = false
+	}
+}
+
+// VisibleChildren returns the children of the receiver, or nil if the
+// receiver is folded.
+func (d *Dirent) VisibleChildren() []*Dirent {
+	if d.folded {
+		return nil
+	}
+	return d.Children()
+}
+
+// ForVisibleChild is like ForChild, but does nothing if the receiver is
+// folded.
+func (d *Dirent) ForVisibleChild(f func(d *Dirent) bool) {
+	if d.folded {
+		return
+	}
+	d.ForChild(f)
+}
@@ -0,0 +1,146 @@
+package dirtree
+
+import "testing"
+
+func buildFoldFixture() (*Dirent, *Dirent, *Dirent) {
+	root := New(".")
+	a, _ := root.Add("A")
+	ax, _ := a.Add("Ax")
+	ax.Add("Ax-1")
+	root.Add("B")
+	return root, a, ax
+}
+
+func TestFoldUnfoldToggle(t *testing.T) {
+	_, a, _ := buildFoldFixture()
+
+	if a.IsFolded() {
+		t.Fatal("expected new entry to start unfolded")
+	}
+
+	a.Fold()
+	if !a.IsFolded() {
+		t.Fatal("expected Fold to set folded state")
+	}
+
+	a.Unfold()
+	if a.IsFolded() {
+		t.Fatal("expected Unfold to clear folded state")
+	}
+
+	if got := a.ToggleFold(); !got || !a.IsFolded() {
+		t.Fatal("expected ToggleFold to fold and return true")
+	}
+	if got := a.ToggleFold(); got || a.IsFolded() {
+		t.Fatal("expected second ToggleFold to unfold and return false")
+	}
+}
+
+func TestUnfoldPath(t *testing.T) {
+	root, a, ax := buildFoldFixture()
+	a.Fold()
+	ax.Fold()
+
+	root.UnfoldPath("A/Ax/Ax-1")
+
+	if a.IsFolded() {
+		t.Error("expected ancestor A to be unfolded")
+	}
+	if ax.IsFolded() {
+		t.Error("expected ancestor Ax to be unfolded")
+	}
+}
+
+func TestUnfoldPathMissing(t *testing.T) {
+	root, a, _ := buildFoldFixture()
+	a.Fold()
+
+	// A no-op for a path that does not exist must not panic or change
+	// any fold state.
+	root.UnfoldPath("A/nope")
+	if !a.IsFolded() {
+		t.Fatal("expected A to remain folded")
+	}
+}
+
+func TestVisibleChildren(t *testing.T) {
+	_, a, _ := buildFoldFixture()
+
+	if len(a.VisibleChildren()) != 1 {
+		t.Fatalf("expected 1 visible child before folding, got %d", len(a.VisibleChildren()))
+	}
+
+	a.Fold()
+	if got := a.VisibleChildren(); got != nil {
+		t.Fatalf("expected no visible children once folded, got %v", got)
+	}
+}
+
+func TestForVisibleChild(t *testing.T) {
+	_, a, _ := buildFoldFixture()
+
+	var count int
+	a.ForVisibleChild(func(*Dirent) bool {
+		count++
+		return true
+	})
+	if count != 1 {
+		t.Fatalf("expected 1 visible child before folding, got %d", count)
+	}
+
+	a.Fold()
+	count = 0
+	a.ForVisibleChild(func(*Dirent) bool {
+		count++
+		return true
+	})
+	if count != 0 {
+		t.Fatalf("expected ForVisibleChild to skip children once folded, got %d", count)
+	}
+}
+
+func TestFoldIgnoredByFindChildrenDiff(t *testing.T) {
+	root, a, ax := buildFoldFixture()
+	a.Fold()
+
+	// Find, Children, and Diff must ignore fold state.
+	if root.Find("Ax-1") != ax.Child("Ax-1") {
+		t.Error("Find should ignore fold state")
+	}
+	if len(a.Children()) != 1 {
+		t.Error("Children should ignore fold state")
+	}
+
+	// other lacks root's Ax/Ax-1 descendants. Diff must still walk into
+	// folded A and report the difference, rather than treating a folded
+	// node as a leaf.
+	other := New(".")
+	other.Add("A")
+	other.Add("B")
+	changes := Diff(root, other)
+	checkChanges(t, changes, []Change{
+		{Action: Delete, From: "./A/Ax"},
+		{Action: Delete, From: "./A/Ax/Ax-1"},
+	})
+}
+
+func TestTreePrinterFoldMarker(t *testing.T) {
+	root, a, _ := buildFoldFixture()
+	a.Fold()
+
+	got := NewTreePrinter(TreeOptions{ShowRoot: true, Sort: Sort}).Sprint(root)
+	want := ".\n" +
+		"|-- A…\n" +
+		"`-- B"
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	got = NewTreePrinter(TreeOptions{ShowRoot: true, Sort: Sort, FoldMarker: " (folded)"}).Sprint(root)
+	want = ".\n" +
+		"|-- A (folded)\n" +
+		"`-- B"
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,81 @@
+package dirtree
+
+import (
+	"path"
+	"sort"
+
+	"github.com/gammazero/queue"
+)
+
+// matchState is a pending (node, pattern segment index) pair explored while
+// matching a pattern against the tree.
+type matchState struct {
+	node *Dirent
+	idx  int
+}
+
+// Match returns every node in the tree rooted at the receiver whose path,
+// relative to the receiver, matches pattern. Patterns are slash-separated
+// path globs: '?' and '*' match within a single path component, '[...]'
+// matches a class of characters (see path.Match), and '**' matches zero or
+// more whole path components (bash/restic-style globstar).
+//
+// For example, root.Match("A/**/Ax-*") returns every node under "A" whose
+// name matches "Ax-*" at any depth.
+//
+// Results are returned in deterministic, sorted path order.
+func (d *Dirent) Match(pattern string) []*Dirent {
+	segs := splitPath(pattern, "/")
+
+	visited := make(map[matchState]bool)
+	var matched []*Dirent
+
+	pending := queue.New()
+	pending.Add(matchState{node: d, idx: 0})
+
+	for pending.Length() > 0 {
+		st := pending.Remove().(matchState)
+		if visited[st] {
+			continue
+		}
+		visited[st] = true
+
+		if st.idx == len(segs) {
+			matched = append(matched, st.node)
+			continue
+		}
+
+		seg := segs[st.idx]
+		if seg == "**" {
+			// Zero components: skip past the "**" without descending.
+			pending.Add(matchState{node: st.node, idx: st.idx + 1})
+			// One or more components: descend and try "**" again.
+			for _, name := range st.node.List() {
+				pending.Add(matchState{node: st.node.children[name], idx: st.idx})
+			}
+			continue
+		}
+
+		for _, name := range st.node.List() {
+			if ok, _ := path.Match(seg, name); ok {
+				pending.Add(matchState{node: st.node.children[name], idx: st.idx + 1})
+			}
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Path() < matched[j].Path()
+	})
+	return matched
+}
+
+// FindPath returns the first node, in sorted path order, in the tree rooted
+// at the receiver whose path matches pattern, or nil if no node matches.
+// See Match for the pattern syntax.
+func (d *Dirent) FindPath(pattern string) *Dirent {
+	matched := d.Match(pattern)
+	if len(matched) == 0 {
+		return nil
+	}
+	return matched[0]
+}
@@ -0,0 +1,103 @@
+package dirtree
+
+import "testing"
+
+func buildMatchTree() *Dirent {
+	root := New("")
+	a, _ := root.Add("A")
+	ax, _ := a.Add("Ax")
+	ax.Add("Ax-1")
+	a.Add("Ax-2")
+	deep, _ := ax.Add("deep")
+	deep.Add("Ax-3")
+	root.Add("B")
+	return root
+}
+
+func matchedPaths(nodes []*Dirent) []string {
+	paths := make([]string, len(nodes))
+	for i, n := range nodes {
+		paths[i] = n.Path()
+	}
+	return paths
+}
+
+func checkMatch(t *testing.T, got []*Dirent, want []string) {
+	t.Helper()
+	gotPaths := matchedPaths(got)
+	if len(gotPaths) != len(want) {
+		t.Fatalf("got %v, want %v", gotPaths, want)
+	}
+	for i, p := range gotPaths {
+		if p != want[i] {
+			t.Fatalf("got %v, want %v", gotPaths, want)
+		}
+	}
+}
+
+func TestMatchWildcard(t *testing.T) {
+	root := buildMatchTree()
+	checkMatch(t, root.Match("A/Ax-*"), []string{"/A/Ax-2"})
+}
+
+func TestMatchQuestionMark(t *testing.T) {
+	root := buildMatchTree()
+	checkMatch(t, root.Match("A/Ax-?"), []string{"/A/Ax-2"})
+}
+
+func TestMatchCharClass(t *testing.T) {
+	root := buildMatchTree()
+	checkMatch(t, root.Match("A/Ax-[0-9]"), []string{"/A/Ax-2"})
+}
+
+func TestMatchLeadingDoubleStar(t *testing.T) {
+	root := buildMatchTree()
+	checkMatch(t, root.Match("**/Ax-1"), []string{"/A/Ax/Ax-1"})
+}
+
+func TestMatchTrailingDoubleStar(t *testing.T) {
+	root := buildMatchTree()
+	checkMatch(t, root.Child("A").Match("Ax/**"), []string{
+		"/A/Ax",
+		"/A/Ax/Ax-1",
+		"/A/Ax/deep",
+		"/A/Ax/deep/Ax-3",
+	})
+}
+
+func TestMatchBareDoubleStar(t *testing.T) {
+	root := buildMatchTree()
+	checkMatch(t, root.Match("**"), []string{
+		"",
+		"/A",
+		"/A/Ax",
+		"/A/Ax-2",
+		"/A/Ax/Ax-1",
+		"/A/Ax/deep",
+		"/A/Ax/deep/Ax-3",
+		"/B",
+	})
+}
+
+func TestMatchMultipleDoubleStar(t *testing.T) {
+	root := buildMatchTree()
+	checkMatch(t, root.Match("**/**/Ax-*"), []string{
+		"/A/Ax-2",
+		"/A/Ax/Ax-1",
+		"/A/Ax/deep/Ax-3",
+	})
+}
+
+func TestFindPath(t *testing.T) {
+	root := buildMatchTree()
+	found := root.FindPath("A/**/Ax-*")
+	if found == nil {
+		t.Fatal("expected a match")
+	}
+	if found.Path() != "/A/Ax-2" {
+		t.Errorf("expected first sorted match /A/Ax-2, got %s", found.Path())
+	}
+	if root.FindPath("nope/*") != nil {
+		t.Error("expected nil when nothing matches")
+	}
+}
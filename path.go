@@ -0,0 +1,125 @@
+package dirtree
+
+import "strings"
+
+// Lookup walks the delim-separated path p, starting at the receiver, and
+// returns the node at that path, or nil if any component of p does not
+// exist. A "." component stays at the current node and a ".." component
+// moves to its parent (staying put if already at the root).
+func (d *Dirent) Lookup(p string) *Dirent {
+	return d.LookupDelim(p, "/")
+}
+
+// LookupDelim is like Lookup, but p is separated by delim instead of "/".
+func (d *Dirent) LookupDelim(p, delim string) *Dirent {
+	node, rest := d.LookupClosestDelim(p, delim)
+	if rest != "" {
+		return nil
+	}
+	return node
+}
+
+// LookupClosest walks the slash-separated path p, starting at the
+// receiver, and returns the deepest existing node along that path together
+// with the unmatched suffix of p. If the full path exists, the returned
+// suffix is empty.
+func (d *Dirent) LookupClosest(p string) (*Dirent, string) {
+	return d.LookupClosestDelim(p, "/")
+}
+
+// LookupClosestDelim is like LookupClosest, but p is separated by delim
+// instead of "/".
+//
+// delim must not be ".": since "." and ".." are recognized as special
+// components by literal value after splitting, using "." as the delimiter
+// makes it impossible for either to appear as a component, and ".."
+// silently fails to navigate to the parent.
+func (d *Dirent) LookupClosestDelim(p, delim string) (*Dirent, string) {
+	comps := splitPath(p, delim)
+	cur := d
+	for i, c := range comps {
+		switch c {
+		case ".":
+			continue
+		case "..":
+			if cur.parent != nil {
+				cur = cur.parent
+			}
+			continue
+		}
+		next, found := cur.children[c]
+		if !found {
+			return cur, strings.Join(comps[i:], delim)
+		}
+		cur = next
+	}
+	return cur, ""
+}
+
+// MkdirAll creates every missing child along the slash-separated path p,
+// starting at the receiver, and returns the leaf node. Existing entries
+// along the path are left unchanged.
+func (d *Dirent) MkdirAll(p string) (*Dirent, error) {
+	return d.MkdirAllDelim(p, "/")
+}
+
+// MkdirAllDelim is like MkdirAll, but p is separated by delim instead of
+// "/". See LookupClosestDelim for the constraint on delim.
+func (d *Dirent) MkdirAllDelim(p, delim string) (*Dirent, error) {
+	comps := splitPath(p, delim)
+	cur := d
+	for _, c := range comps {
+		switch c {
+		case ".":
+			continue
+		case "..":
+			if cur.parent != nil {
+				cur = cur.parent
+			}
+			continue
+		}
+		next, found := cur.children[c]
+		if !found {
+			var err error
+			next, err = cur.Add(c)
+			if err != nil {
+				return nil, err
+			}
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
+// RemoveAll unlinks the subtree rooted at the slash-separated path p,
+// relative to the receiver. It is a no-op if p does not exist.
+func (d *Dirent) RemoveAll(p string) {
+	d.RemoveAllDelim(p, "/")
+}
+
+// RemoveAllDelim is like RemoveAll, but p is separated by delim instead of
+// "/". See LookupClosestDelim for the constraint on delim.
+func (d *Dirent) RemoveAllDelim(p, delim string) {
+	node := d.LookupDelim(p, delim)
+	if node == nil {
+		return
+	}
+	node.Unlink()
+}
+
+// splitPath splits a delim-separated path into its components, discarding
+// empty components caused by leading, trailing, or repeated delimiters.
+func splitPath(p, delim string) []string {
+	if delim == "" {
+		delim = "/"
+	}
+	parts := strings.Split(p, delim)
+	comps := parts[:0]
+	for _, c := range parts {
+		if c == "" {
+			continue
+		}
+		comps = append(comps, c)
+	}
+	return comps
+}
@@ -0,0 +1,126 @@
+package dirtree
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	root := New("")
+	leaf, err := root.MkdirAll("A/B/C")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := root.Lookup("A/B/C"); got != leaf {
+		t.Fatalf("Lookup returned %v, want %v", got, leaf)
+	}
+	if got := root.Lookup("A/nope"); got != nil {
+		t.Fatalf("Lookup for missing path = %v, want nil", got)
+	}
+	if got := root.Lookup(""); got != root {
+		t.Fatalf("Lookup(\"\") = %v, want receiver", got)
+	}
+}
+
+func TestLookupDotAndDotDot(t *testing.T) {
+	root := New("")
+	b, err := root.MkdirAll("A/B")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := root.Lookup("A/./B"); got != b {
+		t.Fatalf("Lookup with '.' = %v, want %v", got, b)
+	}
+	if got := root.Lookup("A/B/.."); got != root.Child("A") {
+		t.Fatalf("Lookup with '..' = %v, want %v", got, root.Child("A"))
+	}
+	// ".." at the root boundary stays at the root instead of returning
+	// nil or panicking.
+	if got := root.Lookup(".."); got != root {
+		t.Fatalf("Lookup('..') at root = %v, want receiver", got)
+	}
+	if got := root.Lookup("../../A"); got != root.Child("A") {
+		t.Fatalf("Lookup('../../A') = %v, want %v", got, root.Child("A"))
+	}
+}
+
+func TestLookupClosest(t *testing.T) {
+	root := New("")
+	b, err := root.MkdirAll("A/B")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node, rest := root.LookupClosest("A/B/C/D")
+	if node != b {
+		t.Fatalf("LookupClosest node = %v, want %v", node, b)
+	}
+	if rest != "C/D" {
+		t.Fatalf("LookupClosest rest = %q, want %q", rest, "C/D")
+	}
+
+	node, rest = root.LookupClosest("A/B")
+	if node != b || rest != "" {
+		t.Fatalf("LookupClosest for full path = (%v, %q), want (%v, \"\")", node, rest, b)
+	}
+}
+
+func TestMkdirAllExistingPath(t *testing.T) {
+	root := New("")
+	leaf, err := root.MkdirAll("A/B/C")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Calling MkdirAll again over an existing, non-empty path must not
+	// error and must return the same leaf without disturbing its
+	// existing children.
+	leaf.Add("existing-child")
+
+	again, err := root.MkdirAll("A/B/C")
+	if err != nil {
+		t.Fatalf("MkdirAll over existing path returned error: %v", err)
+	}
+	if again != leaf {
+		t.Fatalf("MkdirAll over existing path returned %v, want %v", again, leaf)
+	}
+	if again.Child("existing-child") == nil {
+		t.Fatal("MkdirAll over existing path disturbed existing children")
+	}
+}
+
+func TestRemoveAll(t *testing.T) {
+	root := New("")
+	leaf, err := root.MkdirAll("A/B/C")
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf.Add("x")
+
+	root.RemoveAll("A/B")
+	if root.Lookup("A/B") != nil {
+		t.Fatal("expected A/B to be removed")
+	}
+	if root.Lookup("A") == nil {
+		t.Fatal("expected A to remain")
+	}
+
+	// Removing a path that does not exist is a no-op.
+	root.RemoveAll("nope/nope")
+}
+
+// TestLookupClosestDelimDotDelim documents the constraint on
+// LookupClosestDelim/MkdirAllDelim/RemoveAllDelim: delim must not be ".",
+// since splitting on "." consumes any "." or ".." component before it can
+// be recognized, so ".." silently fails to navigate to the parent.
+func TestLookupClosestDelimDotDelim(t *testing.T) {
+	root := New("")
+	b, err := root.MkdirAllDelim("A.B", ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node, rest := root.LookupClosestDelim("A.B...", ".")
+	if node != b || rest != "" {
+		t.Fatalf("got (%v, %q), want (%v, \"\"): '..' with delim \".\" should not navigate to the parent", node, rest, b)
+	}
+}
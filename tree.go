@@ -0,0 +1,189 @@
+package dirtree
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// IndentStyle selects the characters TreePrinter uses to draw branch lines.
+type IndentStyle int
+
+const (
+	// IndentASCII is the classic ASCII tree style: "|-- " and "`-- ".
+	IndentASCII IndentStyle = iota
+	// IndentPlusASCII is a pure-ASCII style that uses "+-- " for every
+	// branch, with no distinct "last entry" glyph.
+	IndentPlusASCII
+	// IndentUnicode uses Unicode box-drawing characters: "├── " and
+	// "└── ".
+	IndentUnicode
+	// IndentFlat indents each level without drawing any branch lines.
+	IndentFlat
+)
+
+// indentGlyphs holds the prefix strings used to render one level of a tree
+// in a given IndentStyle.
+type indentGlyphs struct {
+	link, cont, endl, blank string
+}
+
+var styleGlyphs = map[IndentStyle]indentGlyphs{
+	IndentASCII:     {link: "|-- ", cont: "|   ", endl: "`-- ", blank: "    "},
+	IndentPlusASCII: {link: "+-- ", cont: "|   ", endl: "+-- ", blank: "    "},
+	IndentUnicode:   {link: "├── ", cont: "│   ", endl: "└── ", blank: "    "},
+	IndentFlat:      {link: "", cont: "    ", endl: "", blank: "    "},
+}
+
+// TreeOptions configures how TreePrinter renders a Dirent tree.
+type TreeOptions struct {
+	// Style selects the branch-drawing style. The zero value is
+	// IndentASCII.
+	Style IndentStyle
+	// MaxDepth limits how many levels below the root are rendered. Zero
+	// means no limit.
+	MaxDepth int
+	// ShowRoot includes the receiver itself as the first line of output.
+	ShowRoot bool
+	// Filter, if set, is called for every node including the root; nodes
+	// for which it returns false are omitted along with their children.
+	Filter func(*Dirent) bool
+	// Sort orders the children at each level before they are rendered.
+	// If nil, children are sorted alphabetically.
+	Sort func([]*Dirent)
+	// Decorator, if set, is called for each rendered node and its result
+	// is appended after the node's name, e.g. to add ANSI color or an
+	// informational suffix.
+	Decorator func(*Dirent) string
+	// FoldMarker is appended after the name of a folded node, in place of
+	// its (hidden) children. If empty, defaultFoldMarker is used.
+	FoldMarker string
+}
+
+// defaultFoldMarker is used in place of a folded node's children when
+// TreeOptions.FoldMarker is not set.
+const defaultFoldMarker = "…"
+
+// DefaultTreeOptions returns the TreeOptions used by Dirent.Tree: ASCII
+// indent style, no depth limit, alphabetical order, and the root shown.
+func DefaultTreeOptions() TreeOptions {
+	return TreeOptions{
+		Style:    IndentASCII,
+		ShowRoot: true,
+		Sort:     Sort,
+	}
+}
+
+// TreePrinter renders Dirent trees to text according to a TreeOptions.
+type TreePrinter struct {
+	opts TreeOptions
+}
+
+// NewTreePrinter creates a TreePrinter using the given options.
+func NewTreePrinter(opts TreeOptions) *TreePrinter {
+	return &TreePrinter{opts: opts}
+}
+
+// Sprint returns the pretty-printed tree rooted at d, formatted according
+// to the printer's TreeOptions.
+func (tp *TreePrinter) Sprint(d *Dirent) string {
+	opts := tp.opts
+	if opts.Filter != nil && !opts.Filter(d) {
+		return ""
+	}
+
+	glyphs, ok := styleGlyphs[opts.Style]
+	if !ok {
+		glyphs = styleGlyphs[IndentASCII]
+	}
+	sortFn := opts.Sort
+	if sortFn == nil {
+		sortFn = Sort
+	}
+
+	var lines []string
+	if opts.ShowRoot {
+		lines = append(lines, d.name+foldMarker(opts, d)+decorate(opts.Decorator, d))
+	}
+	if !d.folded {
+		appendChildLines(&lines, d, 0, "", opts, glyphs, sortFn)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func appendChildLines(lines *[]string, node *Dirent, depth int, ppfx string, opts TreeOptions, glyphs indentGlyphs, sortFn func([]*Dirent)) {
+	if opts.MaxDepth > 0 && depth >= opts.MaxDepth {
+		return
+	}
+
+	children := node.Children()
+	if opts.Filter != nil {
+		filtered := children[:0]
+		for _, c := range children {
+			if opts.Filter(c) {
+				filtered = append(filtered, c)
+			}
+		}
+		children = filtered
+	}
+	sortFn(children)
+
+	for i, c := range children {
+		last := i == len(children)-1
+		pfx := glyphs.link
+		var childPpfx string
+		if last {
+			pfx = glyphs.endl
+			childPpfx = ppfx + glyphs.blank
+		} else {
+			childPpfx = ppfx + glyphs.cont
+		}
+		*lines = append(*lines, ppfx+pfx+c.name+foldMarker(opts, c)+decorate(opts.Decorator, c))
+		if !c.folded {
+			appendChildLines(lines, c, depth+1, childPpfx, opts, glyphs, sortFn)
+		}
+	}
+}
+
+func decorate(dec func(*Dirent) string, d *Dirent) string {
+	if dec == nil {
+		return ""
+	}
+	return dec(d)
+}
+
+func foldMarker(opts TreeOptions, d *Dirent) string {
+	if !d.folded {
+		return ""
+	}
+	if opts.FoldMarker != "" {
+		return opts.FoldMarker
+	}
+	return defaultFoldMarker
+}
+
+// treeJSONNode is the JSON representation of a Dirent produced by
+// Dirent.TreeJSON.
+type treeJSONNode struct {
+	Name     string         `json:"name"`
+	Children []treeJSONNode `json:"children,omitempty"`
+}
+
+// TreeJSON returns the tree rooted at the receiver as nested JSON objects
+// of the form {"name":"A","children":[...]}, suitable for machine
+// consumption.
+func (d *Dirent) TreeJSON() ([]byte, error) {
+	return json.Marshal(toTreeJSONNode(d))
+}
+
+func toTreeJSONNode(d *Dirent) treeJSONNode {
+	children := d.Children()
+	Sort(children)
+	node := treeJSONNode{Name: d.name}
+	if len(children) > 0 {
+		node.Children = make([]treeJSONNode, len(children))
+		for i, c := range children {
+			node.Children[i] = toTreeJSONNode(c)
+		}
+	}
+	return node
+}
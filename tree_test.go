@@ -0,0 +1,123 @@
+package dirtree
+
+import "testing"
+
+func buildTreeFixture() *Dirent {
+	root := New(".")
+	a, _ := root.Add("A")
+	a.Add("Ax")
+	root.Add("B")
+	return root
+}
+
+func TestTreePrinterMaxDepth(t *testing.T) {
+	root := buildTreeFixture()
+	got := NewTreePrinter(TreeOptions{ShowRoot: true, Sort: Sort, MaxDepth: 1}).Sprint(root)
+	want := ".\n" +
+		"|-- A\n" +
+		"`-- B"
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTreePrinterFilter(t *testing.T) {
+	root := buildTreeFixture()
+	got := NewTreePrinter(TreeOptions{
+		ShowRoot: true,
+		Sort:     Sort,
+		Filter:   func(d *Dirent) bool { return d.String() != "Ax" },
+	}).Sprint(root)
+	want := ".\n" +
+		"|-- A\n" +
+		"`-- B"
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestTreePrinterFilterRejectsRoot documents current behavior: Filter is
+// also applied to the root itself, so a Filter that rejects the root
+// suppresses the entire output even when ShowRoot is false.
+func TestTreePrinterFilterRejectsRoot(t *testing.T) {
+	root := buildTreeFixture()
+	got := NewTreePrinter(TreeOptions{
+		ShowRoot: false,
+		Sort:     Sort,
+		Filter:   func(d *Dirent) bool { return d != root },
+	}).Sprint(root)
+	if got != "" {
+		t.Fatalf("got %q, want empty string", got)
+	}
+}
+
+func TestTreePrinterCustomSort(t *testing.T) {
+	root := buildTreeFixture()
+	got := NewTreePrinter(TreeOptions{ShowRoot: true, Sort: SortReverse}).Sprint(root)
+	want := ".\n" +
+		"|-- B\n" +
+		"`-- A\n" +
+		"    `-- Ax"
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTreePrinterDecorator(t *testing.T) {
+	root := buildTreeFixture()
+	got := NewTreePrinter(TreeOptions{
+		ShowRoot:  true,
+		Sort:      Sort,
+		Decorator: func(d *Dirent) string { return " [" + d.String() + "]" },
+	}).Sprint(root)
+	want := ". [.]\n" +
+		"|-- A [A]\n" +
+		"|   `-- Ax [Ax]\n" +
+		"`-- B [B]"
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTreePrinterIndentStyles(t *testing.T) {
+	root := buildTreeFixture()
+
+	unicode := NewTreePrinter(TreeOptions{ShowRoot: true, Sort: Sort, Style: IndentUnicode}).Sprint(root)
+	wantUnicode := ".\n" +
+		"├── A\n" +
+		"│   └── Ax\n" +
+		"└── B"
+	if unicode != wantUnicode {
+		t.Fatalf("unicode style:\ngot:\n%s\nwant:\n%s", unicode, wantUnicode)
+	}
+
+	plusASCII := NewTreePrinter(TreeOptions{ShowRoot: true, Sort: Sort, Style: IndentPlusASCII}).Sprint(root)
+	wantPlusASCII := ".\n" +
+		"+-- A\n" +
+		"|   +-- Ax\n" +
+		"+-- B"
+	if plusASCII != wantPlusASCII {
+		t.Fatalf("plus-ASCII style:\ngot:\n%s\nwant:\n%s", plusASCII, wantPlusASCII)
+	}
+
+	flat := NewTreePrinter(TreeOptions{ShowRoot: true, Sort: Sort, Style: IndentFlat}).Sprint(root)
+	wantFlat := ".\n" +
+		"A\n" +
+		"    Ax\n" +
+		"B"
+	if flat != wantFlat {
+		t.Fatalf("flat style:\ngot:\n%s\nwant:\n%s", flat, wantFlat)
+	}
+}
+
+func TestTreeJSON(t *testing.T) {
+	root := buildTreeFixture()
+	got, err := root.TreeJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":".","children":[{"name":"A","children":[{"name":"Ax"}]},{"name":"B"}]}`
+	if string(got) != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}